@@ -0,0 +1,116 @@
+// The jsonschemadoclint command validates JSON/JSONC config files against a JSON Schema, reporting
+// actionable issues (the failing keyword, the offending JSON pointer path, and a human message).
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sourcegraph/go-jsonschema/jsonschema"
+
+	"github.com/sourcegraph/jsonschemadoc"
+)
+
+var schemaFile = flag.String("schema", "", "path to the JSON Schema file to validate against (required)")
+
+func main() {
+	flag.Parse()
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage of %s:\n", os.Args[0])
+		fmt.Fprintln(os.Stderr, "\tjsonschemadoclint -schema schema.json file_or_dir...")
+		fmt.Fprintln(os.Stderr, "Flags:")
+		flag.PrintDefaults()
+	}
+	if *schemaFile == "" || flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	schema, err := readSchema(*schemaFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "jsonschemadoclint: error reading JSON Schema from %s: %s.\n", *schemaFile, err)
+		os.Exit(2)
+	}
+
+	configFiles, err := findConfigFiles(flag.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "jsonschemadoclint: %s.\n", err)
+		os.Exit(2)
+	}
+
+	hadIssues := false
+	for _, path := range configFiles {
+		config, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "jsonschemadoclint: error reading %s: %s.\n", path, err)
+			os.Exit(2)
+		}
+
+		issues, err := jsonschemadoc.Lint(schema, config)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", path, err)
+			hadIssues = true
+			continue
+		}
+		for _, issue := range issues {
+			hadIssues = true
+			fmt.Printf("%s:%d: [%s] %s: %s: %s\n", path, issue.Offset, issue.Severity, issue.Keyword, issue.Path, issue.Message)
+			if issue.Suggestion != "" {
+				fmt.Printf("  did you mean %q?\n", issue.Suggestion)
+			}
+		}
+	}
+
+	if hadIssues {
+		os.Exit(1)
+	}
+}
+
+func readSchema(filename string) (*jsonschema.Schema, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var schema *jsonschema.Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// findConfigFiles expands args (files or directories) into a list of files, in argument order.
+// Directories are walked recursively in lexical order and contribute their *.json/*.jsonc files;
+// file arguments are included as given, whatever their extension.
+func findConfigFiles(args []string) ([]string, error) {
+	var files []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+		err = filepath.Walk(arg, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+			if ext := strings.ToLower(filepath.Ext(path)); ext == ".json" || ext == ".jsonc" {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,530 @@
+package jsonschemadoc
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/sourcegraph/go-jsonschema/jsonschema"
+)
+
+// Document is the structured, renderer-agnostic representation of a JSON Schema's documentable
+// properties. It is produced once by BuildDocument and can be fed to any Writer, so renderers
+// don't need to re-walk the schema themselves.
+type Document struct {
+	Groups      []Group
+	Definitions map[string]*Node
+}
+
+// Group is a named collection of properties. The unnamed group ("") holds properties that don't
+// specify the `group` extension keyword.
+type Group struct {
+	Name       string
+	Properties []*Node
+}
+
+// Node describes a single documentable schema property (or definition).
+type Node struct {
+	Name        string
+	Path        string // JSON pointer path, e.g. "/server/port"
+	Type        []string
+	Default     *any
+	Const       *any
+	Enum        []any
+	Examples    []any
+	Description string
+	Required    bool
+	Deprecated  bool
+
+	// Hide and Group mirror this repo's `hide`/`group` schema extension keywords.
+	Hide  bool
+	Group string
+
+	// Format is the declared JSON Schema `format` name, or "" if unset.
+	Format string
+	// FormatHint is a human-readable description of Format, or "" if Format is unset or unknown.
+	FormatHint string
+
+	// Ref is this node's `$ref`, or "" if it isn't a reference. If the reference could be
+	// resolved, its schema was expanded inline into this Node's other fields; RefNote is set
+	// instead of expanding if doing so would recurse into a cycle.
+	Ref     string
+	RefNote string
+
+	// OneOf and AnyOf list this node's `oneOf`/`anyOf` alternatives, if any.
+	OneOf []Variant
+	AnyOf []Variant
+
+	// Conditional describes this node's `if`/`then`/`else`, if any.
+	Conditional *Conditional
+
+	// Children holds nested object properties, in the case of a property whose schema declares
+	// its own `properties` (including properties merged in via `allOf`).
+	Children []*Node
+
+	// FromAllOf is true if this property was contributed by one of its parent's `allOf` branches
+	// rather than declared directly in the parent's own `properties`. Writers that flatten nested
+	// structure (e.g. JSONWriter) use this to decide what counts as "the same object" as its
+	// parent: an `allOf` branch's properties are part of the parent's own property set, while a
+	// genuinely nested object's properties describe a distinct, nested JSON value.
+	FromAllOf bool
+}
+
+// Variant is one alternative of a `oneOf`/`anyOf` schema: the property set it declares and a
+// representative example value, if one can be derived. RefNote is set instead, the same way
+// Node's is, if the alternative is a `$ref` that would recurse into a cycle.
+type Variant struct {
+	Properties []*Node
+	Example    any
+	RefNote    string
+}
+
+// Conditional describes a schema's `if`/`then`/`else`: the properties that apply `Then` the
+// condition holds, and `Else` if it doesn't.
+type Conditional struct {
+	Condition string
+	Then      []*Node
+	Else      []*Node
+}
+
+// BuildDocument walks schema and produces a Document describing its properties and definitions.
+// It returns an error if a property's `default`, `const`, or `examples` value doesn't satisfy its
+// declared `format`.
+func BuildDocument(schema *jsonschema.Schema, opts Options) (*Document, error) {
+	formats := opts.Formats
+	if formats == nil {
+		formats = DefaultFormatCheckers()
+	}
+	ctx := &buildContext{defs: collectDefs(schema), formats: formats, visited: map[string]bool{}}
+
+	doc := &Document{Definitions: map[string]*Node{}}
+	for name, defSchema := range ctx.defs {
+		// Mark both spellings of this definition's own ref as visited while building it, so a
+		// self-referencing definition (e.g. a recursive "Node" type) is caught as a cycle instead
+		// of recursing forever.
+		ctx.visited["#/definitions/"+name] = true
+		ctx.visited["#/$defs/"+name] = true
+		node, err := ctx.buildNode(name, defSchema, "#/definitions/"+name, false)
+		delete(ctx.visited, "#/definitions/"+name)
+		delete(ctx.visited, "#/$defs/"+name)
+		if err != nil {
+			return nil, err
+		}
+		doc.Definitions[name] = node
+	}
+
+	props, required, _ := mergeSchema(schema, ctx)
+	if len(props) == 0 {
+		return doc, nil
+	}
+
+	byName := map[string]*Group{}
+	var order []string
+	for _, name := range sortedSchemaProps(props) {
+		node, err := ctx.buildNode(name, props[name], "/"+name, required[name])
+		if err != nil {
+			return nil, err
+		}
+		if node.Hide {
+			continue
+		}
+
+		group := byName[node.Group]
+		if group == nil {
+			group = &Group{Name: node.Group}
+			byName[node.Group] = group
+			order = append(order, node.Group)
+		}
+		group.Properties = append(group.Properties, node)
+	}
+	for _, name := range order {
+		doc.Groups = append(doc.Groups, *byName[name])
+	}
+
+	return doc, nil
+}
+
+// buildContext carries the state shared across a single BuildDocument call: the format checker
+// registry, the resolved `$defs`/`definitions` map, and the set of `$ref`s currently being
+// expanded (to detect cycles).
+type buildContext struct {
+	defs    map[string]*jsonschema.Schema
+	formats map[string]FormatChecker
+	visited map[string]bool
+}
+
+func (ctx *buildContext) buildNode(name string, schema *jsonschema.Schema, path string, required bool) (*Node, error) {
+	n := &Node{Name: name, Path: path, Required: required}
+
+	resolved := schema
+	if schema.Reference != nil {
+		n.Ref = *schema.Reference
+		target, cyclic := ctx.resolve(*schema.Reference)
+		if cyclic {
+			n.RefNote = "See: " + n.Ref
+			return n, nil
+		}
+		if target != nil {
+			resolved = target
+			ctx.visited[n.Ref] = true
+			defer delete(ctx.visited, n.Ref)
+		}
+	}
+
+	if err := ctx.populate(n, resolved); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// populate fills in n's fields from schema (which may be a `$ref` target already resolved by the
+// caller).
+func (ctx *buildContext) populate(n *Node, schema *jsonschema.Schema) error {
+	for _, t := range schema.Type {
+		n.Type = append(n.Type, string(t))
+	}
+	if schema.Const != nil {
+		n.Const = schema.Const
+	}
+	if schema.Default != nil {
+		n.Default = schema.Default
+	}
+	if len(schema.Enum) > 0 {
+		n.Enum = make([]any, len(schema.Enum))
+		for i, e := range schema.Enum {
+			n.Enum[i] = e
+		}
+	}
+	n.Examples = schema.Examples
+	if schema.Description != nil {
+		n.Description = *schema.Description
+	}
+
+	var extra struct {
+		Hide       bool
+		Group      string `json:"group"`
+		Deprecated bool   `json:"deprecated"`
+	}
+	if schema.Raw != nil {
+		if err := json.Unmarshal(*schema.Raw, &extra); err != nil {
+			return fmt.Errorf("property %q: %w", n.Name, err)
+		}
+	}
+	n.Hide = extra.Hide
+	n.Group = extra.Group
+	n.Deprecated = extra.Deprecated
+
+	if schema.Format != nil {
+		n.Format = string(*schema.Format)
+		hint, err := validateFormat(n.Name, schema, ctx.formats)
+		if err != nil {
+			return err
+		}
+		n.FormatHint = hint
+	}
+
+	props, required, viaAllOf := mergeSchema(schema, ctx)
+	for _, childName := range sortedSchemaProps(props) {
+		child, err := ctx.buildNode(childName, props[childName], n.Path+"/"+childName, required[childName])
+		if err != nil {
+			return err
+		}
+		child.FromAllOf = viaAllOf[childName]
+		n.Children = append(n.Children, child)
+	}
+
+	for _, alt := range schema.OneOf {
+		v, err := ctx.buildVariant(alt, n.Path)
+		if err != nil {
+			return err
+		}
+		n.OneOf = append(n.OneOf, v)
+	}
+	for _, alt := range schema.AnyOf {
+		v, err := ctx.buildVariant(alt, n.Path)
+		if err != nil {
+			return err
+		}
+		n.AnyOf = append(n.AnyOf, v)
+	}
+
+	if schema.If != nil {
+		cond, err := ctx.buildConditional(schema, n.Path)
+		if err != nil {
+			return err
+		}
+		n.Conditional = cond
+	}
+
+	return nil
+}
+
+func (ctx *buildContext) buildVariant(alt *jsonschema.Schema, path string) (Variant, error) {
+	resolved, cyclic := ctx.resolveSchema(alt)
+	if cyclic {
+		return Variant{RefNote: "See: " + *alt.Reference}, nil
+	}
+
+	props, required, _ := mergeSchema(resolved, ctx)
+	var v Variant
+	for _, name := range sortedSchemaProps(props) {
+		node, err := ctx.buildNode(name, props[name], path+"/"+name, required[name])
+		if err != nil {
+			return Variant{}, err
+		}
+		v.Properties = append(v.Properties, node)
+	}
+	v.Example = variantExample(resolved, v.Properties)
+	return v, nil
+}
+
+// variantExample derives a representative example value for a oneOf/anyOf variant: its own
+// `examples`/`const`/`default` if declared, otherwise one synthesized from its properties' own
+// example/const/default values.
+func variantExample(schema *jsonschema.Schema, properties []*Node) any {
+	if len(schema.Examples) > 0 {
+		return schema.Examples[0]
+	}
+	if schema.Const != nil {
+		return *schema.Const
+	}
+	if schema.Default != nil {
+		return *schema.Default
+	}
+	synthesized := map[string]any{}
+	for _, p := range properties {
+		switch {
+		case p.Const != nil:
+			synthesized[p.Name] = *p.Const
+		case p.Default != nil:
+			synthesized[p.Name] = *p.Default
+		case len(p.Examples) > 0:
+			synthesized[p.Name] = p.Examples[0]
+		}
+	}
+	if len(synthesized) == 0 {
+		return nil
+	}
+	return synthesized
+}
+
+func (ctx *buildContext) buildConditional(schema *jsonschema.Schema, path string) (*Conditional, error) {
+	cond := &Conditional{Condition: describeCondition(schema.If)}
+
+	then, cyclic := ctx.resolveSchema(schema.Then)
+	if schema.Then != nil && !cyclic {
+		nodes, err := ctx.buildBranch(then, path)
+		if err != nil {
+			return nil, err
+		}
+		cond.Then = nodes
+	}
+
+	els, cyclic := ctx.resolveSchema(schema.Else)
+	if schema.Else != nil && !cyclic {
+		nodes, err := ctx.buildBranch(els, path)
+		if err != nil {
+			return nil, err
+		}
+		cond.Else = nodes
+	}
+
+	return cond, nil
+}
+
+func (ctx *buildContext) buildBranch(schema *jsonschema.Schema, path string) ([]*Node, error) {
+	if schema == nil {
+		return nil, nil
+	}
+	props, required, _ := mergeSchema(schema, ctx)
+	var nodes []*Node
+	for _, name := range sortedSchemaProps(props) {
+		node, err := ctx.buildNode(name, props[name], path+"/"+name, required[name])
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// describeCondition summarizes an `if` schema for the "When <condition>:" note, preferring any
+// `const`-valued properties it requires (the common case: "type" : {"const": "foo"}).
+func describeCondition(ifSchema *jsonschema.Schema) string {
+	if ifSchema == nil || ifSchema.Properties == nil {
+		return "the condition holds"
+	}
+	var parts []string
+	for _, name := range sortedSchemaProps(*ifSchema.Properties) {
+		prop := (*ifSchema.Properties)[name]
+		if prop.Const != nil {
+			b, err := json.Marshal(*prop.Const)
+			if err == nil {
+				parts = append(parts, fmt.Sprintf("%s is %s", name, b))
+				continue
+			}
+		}
+		parts = append(parts, name+" is set")
+	}
+	if len(parts) == 0 {
+		return "the condition holds"
+	}
+	return joinWithAnd(parts)
+}
+
+func joinWithAnd(parts []string) string {
+	switch len(parts) {
+	case 1:
+		return parts[0]
+	default:
+		result := parts[0]
+		for i := 1; i < len(parts); i++ {
+			if i == len(parts)-1 {
+				result += " and " + parts[i]
+			} else {
+				result += ", " + parts[i]
+			}
+		}
+		return result
+	}
+}
+
+// resolve resolves ref (a `#/definitions/<name>` or `#/$defs/<name>` reference) against ctx.defs.
+// It reports cyclic=true if ref is already being expanded higher up the call stack (the caller
+// should not recurse further), and returns a nil target if ref can't be resolved at all (e.g. an
+// external or unsupported reference form).
+func (ctx *buildContext) resolve(ref string) (target *jsonschema.Schema, cyclic bool) {
+	if ctx.visited[ref] {
+		return nil, true
+	}
+	name, ok := resolveDefRef(ref)
+	if !ok {
+		return nil, false
+	}
+	return ctx.defs[name], false
+}
+
+// resolveSchema follows schema's `$ref` (if any) to its target, reporting cyclic=true if doing so
+// would recurse into a ref already being expanded.
+func (ctx *buildContext) resolveSchema(schema *jsonschema.Schema) (*jsonschema.Schema, bool) {
+	if schema == nil || schema.Reference == nil {
+		return schema, false
+	}
+	target, cyclic := ctx.resolve(*schema.Reference)
+	if cyclic {
+		return nil, true
+	}
+	if target == nil {
+		return schema, false
+	}
+	return target, false
+}
+
+// mergeSchema returns schema's own `properties` merged with those contributed by its `allOf`
+// members (resolving `$ref` allOf members and recursing into their own `allOf`), along with the
+// union of all `required` property names and the set of property names contributed by an `allOf`
+// member rather than declared directly in schema's own `properties`. Earlier-declared properties
+// win on name conflicts.
+func mergeSchema(schema *jsonschema.Schema, ctx *buildContext) (props map[string]*jsonschema.Schema, required, viaAllOf map[string]bool) {
+	props = map[string]*jsonschema.Schema{}
+	required = map[string]bool{}
+	viaAllOf = map[string]bool{}
+
+	var walk func(s *jsonschema.Schema, fromAllOf bool)
+	walk = func(s *jsonschema.Schema, fromAllOf bool) {
+		resolved, cyclic := ctx.resolveSchema(s)
+		if cyclic || resolved == nil {
+			return
+		}
+		if s.Reference != nil && resolved != s {
+			ctx.visited[*s.Reference] = true
+			defer delete(ctx.visited, *s.Reference)
+		}
+
+		if resolved.Properties != nil {
+			for name, propSchema := range *resolved.Properties {
+				if _, exists := props[name]; !exists {
+					props[name] = propSchema
+					viaAllOf[name] = fromAllOf
+				}
+			}
+		}
+		for _, req := range resolved.Required {
+			required[req] = true
+		}
+		for _, member := range resolved.AllOf {
+			walk(member, true)
+		}
+	}
+	walk(schema, false)
+
+	return props, required, viaAllOf
+}
+
+// validateFormat validates schema's declared `default`, `const`, and `examples` values against its
+// `format` keyword, returning a human-readable description of the format (for use as a
+// self-documenting hint) or an error if a declared value is invalid.
+func validateFormat(name string, schema *jsonschema.Schema, formats map[string]FormatChecker) (string, error) {
+	checker, known := formats[string(*schema.Format)]
+	if !known {
+		return "", nil
+	}
+	_, hint := checker(nil)
+
+	check := func(v interface{}) error {
+		if ok, _ := checker(v); !ok {
+			return fmt.Errorf("property %q: value does not satisfy format %q (expected %s)", name, *schema.Format, hint)
+		}
+		return nil
+	}
+	if schema.Const != nil {
+		if err := check(*schema.Const); err != nil {
+			return "", err
+		}
+	}
+	if schema.Default != nil {
+		if err := check(*schema.Default); err != nil {
+			return "", err
+		}
+	}
+	for _, ex := range schema.Examples {
+		if err := check(ex); err != nil {
+			return "", err
+		}
+	}
+
+	return hint, nil
+}
+
+// collectDefs gathers schema's definitions, keyed by name, from both the draft-07 `definitions`
+// keyword and the `$defs` keyword used by later drafts.
+func collectDefs(schema *jsonschema.Schema) map[string]*jsonschema.Schema {
+	defs := map[string]*jsonschema.Schema{}
+	if schema.Definitions != nil {
+		for name, s := range *schema.Definitions {
+			defs[name] = s
+		}
+	}
+	if schema.Raw != nil {
+		var extra struct {
+			Defs map[string]*jsonschema.Schema `json:"$defs"`
+		}
+		if err := json.Unmarshal(*schema.Raw, &extra); err == nil {
+			for name, s := range extra.Defs {
+				if _, exists := defs[name]; !exists {
+					defs[name] = s
+				}
+			}
+		}
+	}
+	return defs
+}
+
+func sortedSchemaProps(m map[string]*jsonschema.Schema) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,268 @@
+package jsonschemadoc
+
+import "testing"
+
+func TestBuildDocument(t *testing.T) {
+	schema := parseJSONSchema(t, `
+{
+  "type": "object",
+  "properties": {
+    "a": {
+      "description": "b",
+      "type": "object",
+      "required": ["c"],
+      "properties": {
+        "c": {"type": "number"},
+        "d": {"type": "string", "deprecated": true}
+      }
+    },
+    "e": {"$ref": "#/definitions/Widget"}
+  },
+  "required": ["a"],
+  "definitions": {
+    "Widget": {"type": "string"}
+  }
+}`)
+
+	doc, err := BuildDocument(&schema, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(doc.Groups) != 1 || len(doc.Groups[0].Properties) != 2 {
+		t.Fatalf("got %d groups, want 1 group with 2 properties", len(doc.Groups))
+	}
+
+	a := doc.Groups[0].Properties[0]
+	if a.Name != "a" || !a.Required {
+		t.Fatalf("got %+v, want required property \"a\"", a)
+	}
+	if len(a.Children) != 2 {
+		t.Fatalf("got %d children of \"a\", want 2", len(a.Children))
+	}
+	if c := a.Children[0]; c.Name != "c" || !c.Required {
+		t.Errorf("got %+v, want required child \"c\"", c)
+	}
+	if d := a.Children[1]; d.Name != "d" || !d.Deprecated {
+		t.Errorf("got %+v, want deprecated child \"d\"", d)
+	}
+
+	e := doc.Groups[0].Properties[1]
+	if e.Ref != "#/definitions/Widget" {
+		t.Errorf("got ref %q, want #/definitions/Widget", e.Ref)
+	}
+
+	widget, ok := doc.Definitions["Widget"]
+	if !ok {
+		t.Fatal("missing \"Widget\" definition")
+	}
+	if len(widget.Type) != 1 || widget.Type[0] != "string" {
+		t.Errorf("got %+v, want type [string]", widget.Type)
+	}
+}
+
+func TestBuildDocumentAllOf(t *testing.T) {
+	schema := parseJSONSchema(t, `
+{
+  "type": "object",
+  "properties": {
+    "a": {
+      "type": "object",
+      "allOf": [
+        {"properties": {"x": {"type": "string"}}},
+        {"properties": {"y": {"type": "string"}}}
+      ]
+    }
+  }
+}`)
+
+	doc, err := BuildDocument(&schema, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := doc.Groups[0].Properties[0]
+	if len(a.Children) != 2 {
+		t.Fatalf("got %d children, want 2 (merged via allOf)", len(a.Children))
+	}
+	if a.Children[0].Name != "x" || a.Children[1].Name != "y" {
+		t.Errorf("got children %+v, want x, y", a.Children)
+	}
+}
+
+func TestBuildDocumentAllOfRequired(t *testing.T) {
+	schema := parseJSONSchema(t, `
+{
+  "type": "object",
+  "properties": {
+    "a": {
+      "type": "object",
+      "allOf": [
+        {"properties": {"x": {"type": "string"}}, "required": ["x"]},
+        {"properties": {"y": {"type": "string"}}}
+      ]
+    }
+  }
+}`)
+
+	doc, err := BuildDocument(&schema, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := doc.Groups[0].Properties[0]
+	if len(a.Children) != 2 {
+		t.Fatalf("got %d children, want 2 (merged via allOf)", len(a.Children))
+	}
+	if x := a.Children[0]; x.Name != "x" || !x.Required {
+		t.Errorf("got %+v, want required child \"x\" (required by its allOf branch)", x)
+	}
+	if y := a.Children[1]; y.Name != "y" || y.Required {
+		t.Errorf("got %+v, want non-required child \"y\"", y)
+	}
+}
+
+func TestBuildDocumentOneOfCyclicRef(t *testing.T) {
+	schema := parseJSONSchema(t, `
+{
+  "type": "object",
+  "properties": {
+    "a": {"$ref": "#/definitions/Node"}
+  },
+  "definitions": {
+    "Node": {
+      "type": "object",
+      "oneOf": [
+        {"$ref": "#/definitions/Node"},
+        {"properties": {"leaf": {"type": "string"}}}
+      ]
+    }
+  }
+}`)
+
+	doc, err := BuildDocument(&schema, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node, ok := doc.Definitions["Node"]
+	if !ok {
+		t.Fatal(`missing "Node" definition`)
+	}
+	if len(node.OneOf) != 2 {
+		t.Fatalf("got %d oneOf variants, want 2", len(node.OneOf))
+	}
+	if node.OneOf[0].RefNote == "" {
+		t.Error("got empty RefNote on cyclic oneOf variant, want a back-pointer note")
+	}
+	if len(node.OneOf[0].Properties) != 0 {
+		t.Errorf("got %d properties on cyclic oneOf variant, want 0 (expansion stopped)", len(node.OneOf[0].Properties))
+	}
+	if node.OneOf[1].Properties[0].Name != "leaf" {
+		t.Errorf("got variant %+v, want [leaf]", node.OneOf[1])
+	}
+}
+
+func TestBuildDocumentOneOfAnyOf(t *testing.T) {
+	schema := parseJSONSchema(t, `
+{
+  "type": "object",
+  "properties": {
+    "a": {
+      "type": "object",
+      "oneOf": [
+        {"properties": {"x": {"type": "string", "default": "1"}}},
+        {"properties": {"y": {"type": "string", "default": "2"}}}
+      ]
+    }
+  }
+}`)
+
+	doc, err := BuildDocument(&schema, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := doc.Groups[0].Properties[0]
+	if len(a.OneOf) != 2 {
+		t.Fatalf("got %d oneOf variants, want 2", len(a.OneOf))
+	}
+	if a.OneOf[0].Properties[0].Name != "x" || a.OneOf[1].Properties[0].Name != "y" {
+		t.Errorf("got variants %+v, want x, y", a.OneOf)
+	}
+	if a.OneOf[0].Example == nil {
+		t.Errorf("got nil example for first variant, want one synthesized from its properties")
+	}
+}
+
+func TestBuildDocumentConditional(t *testing.T) {
+	schema := parseJSONSchema(t, `
+{
+  "type": "object",
+  "properties": {
+    "a": {
+      "type": "object",
+      "if": {"properties": {"kind": {"const": "foo"}}},
+      "then": {"properties": {"x": {"type": "string"}}},
+      "else": {"properties": {"y": {"type": "string"}}}
+    }
+  }
+}`)
+
+	doc, err := BuildDocument(&schema, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := doc.Groups[0].Properties[0]
+	if a.Conditional == nil {
+		t.Fatal("got nil Conditional, want one from if/then/else")
+	}
+	if a.Conditional.Condition != `kind is "foo"` {
+		t.Errorf("got condition %q, want `kind is \"foo\"`", a.Conditional.Condition)
+	}
+	if len(a.Conditional.Then) != 1 || a.Conditional.Then[0].Name != "x" {
+		t.Errorf("got then %+v, want [x]", a.Conditional.Then)
+	}
+	if len(a.Conditional.Else) != 1 || a.Conditional.Else[0].Name != "y" {
+		t.Errorf("got else %+v, want [y]", a.Conditional.Else)
+	}
+}
+
+func TestBuildDocumentRefCycle(t *testing.T) {
+	schema := parseJSONSchema(t, `
+{
+  "type": "object",
+  "properties": {
+    "a": {"$ref": "#/definitions/Node"}
+  },
+  "definitions": {
+    "Node": {
+      "type": "object",
+      "properties": {
+        "next": {"$ref": "#/definitions/Node"}
+      }
+    }
+  }
+}`)
+
+	doc, err := BuildDocument(&schema, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node, ok := doc.Definitions["Node"]
+	if !ok {
+		t.Fatal(`missing "Node" definition`)
+	}
+	if len(node.Children) != 1 || node.Children[0].Name != "next" {
+		t.Fatalf("got children %+v, want [next]", node.Children)
+	}
+	next := node.Children[0]
+	if next.RefNote == "" {
+		t.Error("got empty RefNote on cyclic $ref, want a back-pointer note")
+	}
+	if len(next.Children) != 0 {
+		t.Errorf("got %d children on cyclic $ref, want 0 (expansion stopped)", len(next.Children))
+	}
+}
@@ -0,0 +1,131 @@
+package jsonschemadoc
+
+import (
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+// FormatChecker validates a value against a JSON Schema "format" name. It reports whether v
+// satisfies the format and a human-readable description of the format, used both as the expected
+// format in the error reported when v is invalid and as a self-documenting hint in generated output.
+type FormatChecker func(v interface{}) (ok bool, hint string)
+
+// DefaultFormatCheckers returns the built-in format checkers, keyed by JSON Schema "format" name.
+func DefaultFormatCheckers() map[string]FormatChecker {
+	return map[string]FormatChecker{
+		"duration":  checkDuration,
+		"date-time": checkDateTime,
+		"date":      checkDate,
+		"time":      checkTime,
+		"uri":       checkURI,
+		"email":     checkEmail,
+		"ipv4":      checkIPv4,
+		"ipv6":      checkIPv6,
+		"regex":     checkRegex,
+		"hostname":  checkHostname,
+	}
+}
+
+var hostnameRE = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,62}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,62}[a-zA-Z0-9])?)*$`)
+
+func checkDuration(v interface{}) (bool, string) {
+	const hint = "a duration parseable by time.ParseDuration, e.g. 30s, 5m, 1h30m"
+	s, ok := v.(string)
+	if !ok {
+		return true, hint
+	}
+	_, err := time.ParseDuration(s)
+	return err == nil, hint
+}
+
+func checkDateTime(v interface{}) (bool, string) {
+	const hint = "an RFC 3339 date-time, e.g. 2006-01-02T15:04:05Z"
+	s, ok := v.(string)
+	if !ok {
+		return true, hint
+	}
+	_, err := time.Parse(time.RFC3339, s)
+	return err == nil, hint
+}
+
+func checkDate(v interface{}) (bool, string) {
+	const hint = "an RFC 3339 full-date, e.g. 2006-01-02"
+	s, ok := v.(string)
+	if !ok {
+		return true, hint
+	}
+	_, err := time.Parse("2006-01-02", s)
+	return err == nil, hint
+}
+
+func checkTime(v interface{}) (bool, string) {
+	const hint = "an RFC 3339 full-time, e.g. 15:04:05Z"
+	s, ok := v.(string)
+	if !ok {
+		return true, hint
+	}
+	_, err := time.Parse("15:04:05Z07:00", s)
+	return err == nil, hint
+}
+
+func checkURI(v interface{}) (bool, string) {
+	const hint = "an absolute URI, e.g. https://example.com/path"
+	s, ok := v.(string)
+	if !ok {
+		return true, hint
+	}
+	u, err := url.Parse(s)
+	return err == nil && u.IsAbs(), hint
+}
+
+func checkEmail(v interface{}) (bool, string) {
+	const hint = "a valid email address, e.g. user@example.com"
+	s, ok := v.(string)
+	if !ok {
+		return true, hint
+	}
+	_, err := mail.ParseAddress(s)
+	return err == nil, hint
+}
+
+func checkIPv4(v interface{}) (bool, string) {
+	const hint = "an IPv4 address, e.g. 192.0.2.1"
+	s, ok := v.(string)
+	if !ok {
+		return true, hint
+	}
+	ip := net.ParseIP(s)
+	return ip != nil && ip.To4() != nil, hint
+}
+
+func checkIPv6(v interface{}) (bool, string) {
+	const hint = "an IPv6 address, e.g. ::1"
+	s, ok := v.(string)
+	if !ok {
+		return true, hint
+	}
+	ip := net.ParseIP(s)
+	return ip != nil && ip.To4() == nil, hint
+}
+
+func checkRegex(v interface{}) (bool, string) {
+	const hint = "a valid regular expression"
+	s, ok := v.(string)
+	if !ok {
+		return true, hint
+	}
+	_, err := regexp.Compile(s)
+	return err == nil, hint
+}
+
+func checkHostname(v interface{}) (bool, string) {
+	const hint = "a valid RFC 1123 hostname, e.g. example.com"
+	s, ok := v.(string)
+	if !ok {
+		return true, hint
+	}
+	return len(s) <= 253 && hostnameRE.MatchString(s), hint
+}
@@ -0,0 +1,40 @@
+package jsonschemadoc
+
+import "testing"
+
+func TestDefaultFormatCheckers(t *testing.T) {
+	tests := map[string]struct {
+		format string
+		value  interface{}
+		want   bool
+	}{
+		"duration valid":    {"duration", "1h30m", true},
+		"duration invalid":  {"duration", "soon", false},
+		"date-time valid":   {"date-time", "2006-01-02T15:04:05Z", true},
+		"date-time invalid": {"date-time", "2006-01-02", false},
+		"uri valid":         {"uri", "https://example.com/path", true},
+		"uri invalid":       {"uri", "not a uri", false},
+		"email valid":       {"email", "user@example.com", true},
+		"email invalid":     {"email", "not-an-email", false},
+		"ipv4 valid":        {"ipv4", "192.0.2.1", true},
+		"ipv4 invalid":      {"ipv4", "::1", false},
+		"ipv6 valid":        {"ipv6", "::1", true},
+		"ipv6 invalid":      {"ipv6", "192.0.2.1", false},
+		"regex valid":       {"regex", "^[a-z]+$", true},
+		"regex invalid":     {"regex", "(unclosed", false},
+		"hostname valid":    {"hostname", "example.com", true},
+		"hostname invalid":  {"hostname", "not a hostname!", false},
+	}
+	checkers := DefaultFormatCheckers()
+	for label, test := range tests {
+		t.Run(label, func(t *testing.T) {
+			checker, ok := checkers[test.format]
+			if !ok {
+				t.Fatalf("no checker registered for format %q", test.format)
+			}
+			if got, _ := checker(test.value); got != test.want {
+				t.Errorf("checkers[%q](%v) = %v, want %v", test.format, test.value, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package jsonschemadoc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/sourcegraph/go-jsonschema/jsonschema"
+)
+
+// GenerateHTML generates an HTML reference documentation page for a JSON Schema's properties.
+func GenerateHTML(schema *jsonschema.Schema) (string, error) {
+	doc, err := BuildDocument(schema, Options{})
+	if err != nil {
+		return "", err
+	}
+	return (&HTMLWriter{}).Write(doc)
+}
+
+// HTMLWriter renders a Document as a single self-contained HTML page: one <section> per property,
+// with nested object properties recursing into nested <section>s, and `$ref`s rendered as links to
+// the referenced definition's section.
+type HTMLWriter struct{}
+
+func (w *HTMLWriter) Write(doc *Document) (string, error) {
+	r := &htmlRenderer{anchorCounts: map[string]int{}}
+	r.buf.WriteString("<!DOCTYPE html>\n<html>\n<body>\n")
+
+	for _, group := range doc.Groups {
+		for _, n := range group.Properties {
+			if err := r.writeNode(n, 2); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if len(doc.Definitions) > 0 {
+		r.buf.WriteString("<h2>Definitions</h2>\n")
+		for _, name := range sortedDefNames(doc.Definitions) {
+			if err := r.writeNode(doc.Definitions[name], 3); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	r.buf.WriteString("</body>\n</html>\n")
+	return r.buf.String(), nil
+}
+
+// htmlRenderer carries the state shared across a single Write call: the output buffer and the
+// anchor-disambiguation counters, so two properties that happen to share a name (e.g. sibling
+// objects each declaring their own "name") don't collide on the same `id`.
+type htmlRenderer struct {
+	buf          bytes.Buffer
+	anchorCounts map[string]int
+}
+
+// anchorFor returns a GitHub-style anchor slug for title, disambiguating repeats the way
+// markdownRenderer.anchorFor does (appending -1, -2, ...).
+func (r *htmlRenderer) anchorFor(title string) string {
+	base := slugify(title)
+	n := r.anchorCounts[base]
+	r.anchorCounts[base]++
+	if n == 0 {
+		return base
+	}
+	return fmt.Sprintf("%s-%d", base, n)
+}
+
+func (r *htmlRenderer) writeNode(n *Node, level int) error {
+	buf := &r.buf
+	anchor := r.anchorFor(n.Name)
+	fmt.Fprintf(buf, "<section id=%q>\n", anchor)
+	fmt.Fprintf(buf, "<h%d>%s</h%d>\n", level, html.EscapeString(n.Name), level)
+	buf.WriteString("<dl>\n")
+	if len(n.Type) > 0 {
+		fmt.Fprintf(buf, "<dt>Type</dt><dd><code>%s</code></dd>\n", html.EscapeString(strings.Join(n.Type, " | ")))
+	}
+	if n.Required {
+		buf.WriteString("<dt>Required</dt><dd>yes</dd>\n")
+	}
+	if n.Deprecated {
+		buf.WriteString("<dt>Deprecated</dt><dd>yes</dd>\n")
+	}
+	if n.Const != nil {
+		b, err := json.Marshal(*n.Const)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(buf, "<dt>Const</dt><dd><code>%s</code></dd>\n", html.EscapeString(string(b)))
+	}
+	if n.Default != nil {
+		b, err := json.Marshal(*n.Default)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(buf, "<dt>Default</dt><dd><code>%s</code></dd>\n", html.EscapeString(string(b)))
+	}
+	if len(n.Enum) > 0 {
+		vals := make([]string, len(n.Enum))
+		for i, e := range n.Enum {
+			b, err := json.Marshal(e)
+			if err != nil {
+				return err
+			}
+			vals[i] = string(b)
+		}
+		fmt.Fprintf(buf, "<dt>Enum</dt><dd><code>%s</code></dd>\n", html.EscapeString(strings.Join(vals, ", ")))
+	}
+	if n.Format != "" {
+		fmt.Fprintf(buf, "<dt>Format</dt><dd><code>%s</code></dd>\n", html.EscapeString(n.Format))
+	}
+	if n.Description != "" {
+		fmt.Fprintf(buf, "<dt>Description</dt><dd>%s</dd>\n", html.EscapeString(n.Description))
+	}
+	if n.Ref != "" {
+		if defName, ok := resolveDefRef(n.Ref); ok {
+			fmt.Fprintf(buf, "<dt>See also</dt><dd><a href=\"#%s\">%s</a></dd>\n", slugify(defName), html.EscapeString(defName))
+		}
+	}
+	if n.RefNote != "" {
+		fmt.Fprintf(buf, "<dt>See also</dt><dd>%s</dd>\n", html.EscapeString(n.RefNote))
+	}
+	buf.WriteString("</dl>\n")
+	if len(n.OneOf) > 0 {
+		r.writeVariants("One of", n.OneOf)
+	}
+	if len(n.AnyOf) > 0 {
+		r.writeVariants("Any of", n.AnyOf)
+	}
+	if n.Conditional != nil {
+		r.writeConditional(n.Conditional)
+	}
+
+	if len(n.Examples) > 0 {
+		buf.WriteString("<p>Examples:</p>\n")
+		for _, x := range n.Examples {
+			b, err := json.MarshalIndent(x, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(buf, "<pre><code>%s</code></pre>\n", html.EscapeString(string(b)))
+		}
+	}
+
+	for _, child := range n.Children {
+		if err := r.writeNode(child, level+1); err != nil {
+			return err
+		}
+	}
+
+	buf.WriteString("</section>\n")
+	return nil
+}
+
+// writeVariants writes a oneOf/anyOf alternative list: one list item per variant naming its
+// properties and, if one could be derived, an example value.
+func (r *htmlRenderer) writeVariants(heading string, variants []Variant) {
+	buf := &r.buf
+	fmt.Fprintf(buf, "<p>%s:</p>\n<ul>\n", heading)
+	for _, v := range variants {
+		if v.RefNote != "" {
+			fmt.Fprintf(buf, "<li>%s</li>\n", html.EscapeString(v.RefNote))
+			continue
+		}
+		names := make([]string, len(v.Properties))
+		for i, p := range v.Properties {
+			names[i] = p.Name
+		}
+		fmt.Fprintf(buf, "<li>%s", html.EscapeString(strings.Join(names, ", ")))
+		if v.Example != nil {
+			if ex, err := json.Marshal(v.Example); err == nil {
+				fmt.Fprintf(buf, " — e.g. <code>%s</code>", html.EscapeString(string(ex)))
+			}
+		}
+		buf.WriteString("</li>\n")
+	}
+	buf.WriteString("</ul>\n")
+}
+
+// writeConditional writes an if/then/else note describing which properties apply depending on the
+// condition.
+func (r *htmlRenderer) writeConditional(c *Conditional) {
+	buf := &r.buf
+	fmt.Fprintf(buf, "<p>When %s:</p>\n<ul>\n", html.EscapeString(c.Condition))
+	if len(c.Then) > 0 {
+		fmt.Fprintf(buf, "<li>then: %s</li>\n", html.EscapeString(strings.Join(nodeNames(c.Then), ", ")))
+	}
+	if len(c.Else) > 0 {
+		fmt.Fprintf(buf, "<li>else: %s</li>\n", html.EscapeString(strings.Join(nodeNames(c.Else), ", ")))
+	}
+	buf.WriteString("</ul>\n")
+}
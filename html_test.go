@@ -0,0 +1,68 @@
+package jsonschemadoc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateHTML(t *testing.T) {
+	schema := parseJSONSchema(t, `
+{
+  "type": "object",
+  "properties": {
+    "a": {
+      "description": "b",
+      "type": "string",
+      "default": "c"
+    }
+  }
+}`)
+	out, err := GenerateHTML(&schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<section id="a">`,
+		`<h2>a</h2>`,
+		`<dt>Type</dt><dd><code>string</code></dd>`,
+		`<dt>Default</dt><dd><code>&#34;c&#34;</code></dd>`,
+		`<dt>Description</dt><dd>b</dd>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q\n\ngot:\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerateHTMLDisambiguatesDuplicateAnchors(t *testing.T) {
+	schema := parseJSONSchema(t, `
+{
+  "type": "object",
+  "properties": {
+    "server": {
+      "type": "object",
+      "properties": {
+        "name": {"type": "string"}
+      }
+    },
+    "client": {
+      "type": "object",
+      "properties": {
+        "name": {"type": "string"}
+      }
+    }
+  }
+}`)
+	out, err := GenerateHTML(&schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`<section id="name">`, `<section id="name-1">`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q\n\ngot:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, `id="name">`) != 1 {
+		t.Errorf("got more than one element with id=\"name\"\n\ngot:\n%s", out)
+	}
+}
@@ -3,36 +3,61 @@ package jsonschemadoc
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"sort"
 	"strings"
 
 	"github.com/sourcegraph/go-jsonschema/jsonschema"
 )
 
+// Options controls optional behavior of BuildDocument (and, transitively, Generate and the other
+// Generate* convenience functions).
+type Options struct {
+	// Formats is the registry of format checkers, keyed by JSON Schema "format" name, used to
+	// validate `default`/`const`/`examples` values and to annotate properties with a "Format: "
+	// hint comment. If nil, DefaultFormatCheckers is used.
+	Formats map[string]FormatChecker
+}
+
+// Writer renders a Document produced by BuildDocument. Implementations include JSONWriter (the
+// original commented-JSON format), MarkdownWriter, HTMLWriter, and ModelWriter.
+type Writer interface {
+	Write(doc *Document) (string, error)
+}
+
 // Generate generates a JSON document that describes the JSON Schema's properties.
 func Generate(schema *jsonschema.Schema) (string, error) {
-	var buf bytes.Buffer
-
-	buf.WriteByte('{')
-	enc := json.NewEncoder(&buf)
+	return GenerateWithOptions(schema, Options{})
+}
 
-	groups, err := generate(schema)
+// GenerateWithOptions is like Generate, but accepts Options to customize generation.
+func GenerateWithOptions(schema *jsonschema.Schema, opts Options) (string, error) {
+	doc, err := BuildDocument(schema, opts)
 	if err != nil {
 		return "", err
 	}
+	return (&JSONWriter{}).Write(doc)
+}
 
-	// Sort for determinism.
-	sort.Slice(groups, func(i, j int) bool {
-		return groups[i].name < groups[j].name
-	})
-	for _, group := range groups {
-		sort.Slice(group.properties, func(i, j int) bool {
-			if group.properties[i].first == group.properties[j].first {
-				return group.properties[i].name < group.properties[j].name
-			}
-			return group.properties[i].first
-		})
-	}
+// JSONWriter renders a Document as a JSON document annotated with "//" doc comments, e.g. for use
+// as an example config file. Top-level properties, together with the properties merged into them
+// via `allOf`, are listed flat and bucketed by their `group` extension keyword, matching this repo's
+// original output format; properties of a nested object schema are not listed.
+type JSONWriter struct{}
+
+// flatNode is a Node paired with the flattened value (const or default) JSONWriter shows for it.
+type flatNode struct {
+	node  *Node
+	value *any
+	first bool // show this property at the top of its group
+}
+
+func (w *JSONWriter) Write(doc *Document) (string, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	enc := json.NewEncoder(&buf)
+
+	groups := flattenGroups(doc)
 
 	totalProps := 0
 	for _, group := range groups {
@@ -57,39 +82,40 @@ func Generate(schema *jsonschema.Schema) (string, error) {
 			buf.WriteByte('\n')
 		}
 
-		for i, prop := range group.properties {
+		for i, fn := range group.properties {
 			pi++
 			if i == 0 {
 				buf.WriteByte('\n')
 			} else {
 				buf.WriteString("\n\n")
 			}
-			if prop.comment != "" {
-				if err := writeJSONComment(&buf, "\t", " ", prop.comment); err != nil {
+			comment := propertyComment(fn.node)
+			if comment != "" {
+				if err := writeJSONComment(&buf, "\t", " ", comment); err != nil {
 					return "", err
 				}
 				buf.WriteByte('\n')
 			}
 			buf.WriteByte('\t')
 			enc.SetIndent("\t", "\t")
-			if err := writeJSONValue(enc, &buf, prop.name); err != nil {
+			if err := writeJSONValue(enc, &buf, fn.node.Name); err != nil {
 				return "", err
 			}
 			buf.WriteString(": ")
-			if err := writeJSONValue(enc, &buf, prop.value); err != nil {
+			if err := writeJSONValue(enc, &buf, fn.value); err != nil {
 				return "", err
 			}
 			if pi != totalProps {
 				buf.WriteByte(',')
 			}
 
-			if len(prop.examples) > 0 {
+			if len(fn.node.Examples) > 0 {
 				buf.WriteByte('\n')
 				if err := writeJSONComment(&buf, "\t", " ", "Other example values:"); err != nil {
 					return "", err
 				}
 				buf.WriteByte('\n')
-				for i, x := range prop.examples {
+				for i, x := range fn.node.Examples {
 					if i > 0 {
 						buf.WriteByte('\n')
 					}
@@ -113,6 +139,141 @@ func Generate(schema *jsonschema.Schema) (string, error) {
 	return buf.String(), nil
 }
 
+type flatGroup struct {
+	name       string
+	properties []flatNode
+}
+
+// flattenGroups flattens doc's top-level properties, together with any children contributed via
+// `allOf` (which describe the same JSON object as their parent, not a nested one), into groups
+// bucketed by each node's own Group field, sorted for determinism, with const-valued properties
+// shown first within their group. This reproduces the flat layout this package has always
+// rendered as JSON; a property whose schema declares genuinely nested `properties` (not via
+// `allOf`) isn't walked into, since JSONWriter has no way to render nested JSON structure.
+func flattenGroups(doc *Document) []flatGroup {
+	byName := map[string]*flatGroup{}
+	var order []string
+	var collect func(nodes []*Node)
+	collect = func(nodes []*Node) {
+		for _, n := range nodes {
+			fn := flatNode{node: n}
+			if n.Const != nil {
+				fn.value = n.Const
+				fn.first = true
+			} else if n.Default != nil {
+				fn.value = n.Default
+			}
+
+			g := byName[n.Group]
+			if g == nil {
+				g = &flatGroup{name: n.Group}
+				byName[n.Group] = g
+				order = append(order, n.Group)
+			}
+			g.properties = append(g.properties, fn)
+
+			var inline []*Node
+			for _, child := range n.Children {
+				if child.FromAllOf {
+					inline = append(inline, child)
+				}
+			}
+			collect(inline)
+		}
+	}
+	for _, group := range doc.Groups {
+		collect(group.Properties)
+	}
+
+	groups := make([]flatGroup, len(order))
+	for i, name := range order {
+		groups[i] = *byName[name]
+	}
+
+	sort.Slice(groups, func(i, j int) bool { return groups[i].name < groups[j].name })
+	for _, group := range groups {
+		sort.Slice(group.properties, func(i, j int) bool {
+			if group.properties[i].first == group.properties[j].first {
+				return group.properties[i].node.Name < group.properties[j].node.Name
+			}
+			return group.properties[i].first
+		})
+	}
+	return groups
+}
+
+// propertyComment builds the doc comment shown above a property: its description, format hint,
+// oneOf/anyOf variant list, if/then/else note, and $ref back-pointer, in that order, separated by
+// blank lines.
+func propertyComment(n *Node) string {
+	var parts []string
+	if n.Description != "" {
+		parts = append(parts, n.Description)
+	}
+	if n.FormatHint != "" {
+		parts = append(parts, "Format: "+n.Format+" — "+n.FormatHint)
+	}
+	if len(n.OneOf) > 0 {
+		parts = append(parts, renderVariants("One of:", n.OneOf))
+	}
+	if len(n.AnyOf) > 0 {
+		parts = append(parts, renderVariants("Any of:", n.AnyOf))
+	}
+	if n.Conditional != nil {
+		parts = append(parts, renderConditional(n.Conditional))
+	}
+	if n.RefNote != "" {
+		parts = append(parts, n.RefNote)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// renderVariants renders a oneOf/anyOf comment block: a heading line followed by each
+// alternative's property names and an example value.
+func renderVariants(heading string, variants []Variant) string {
+	var b strings.Builder
+	b.WriteString(heading)
+	for i, v := range variants {
+		if v.RefNote != "" {
+			fmt.Fprintf(&b, "\n  %d. %s", i+1, v.RefNote)
+			continue
+		}
+		names := make([]string, len(v.Properties))
+		for j, p := range v.Properties {
+			names[j] = p.Name
+		}
+		fmt.Fprintf(&b, "\n  %d. %s", i+1, strings.Join(names, ", "))
+		if v.Example != nil {
+			if ex, err := json.Marshal(v.Example); err == nil {
+				fmt.Fprintf(&b, " (e.g. %s)", ex)
+			}
+		}
+	}
+	return b.String()
+}
+
+// renderConditional renders an if/then/else comment block describing which properties apply
+// depending on the condition.
+func renderConditional(c *Conditional) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "When %s:", c.Condition)
+	if len(c.Then) > 0 {
+		fmt.Fprintf(&b, "\n  then: %s", strings.Join(nodeNames(c.Then), ", "))
+	}
+	if len(c.Else) > 0 {
+		fmt.Fprintf(&b, "\n  else: %s", strings.Join(nodeNames(c.Else), ", "))
+	}
+	return b.String()
+}
+
+func nodeNames(nodes []*Node) []string {
+	names := make([]string, len(nodes))
+	for i, n := range nodes {
+		names[i] = n.Name
+	}
+	return names
+}
+
 func marshalIndentIfLong(v interface{}, prefix, indent string) ([]byte, error) {
 	const longChars = 30
 	b, err := json.Marshal(v)
@@ -145,93 +306,3 @@ func writeJSONValue(enc *json.Encoder, buf *bytes.Buffer, v interface{}) error {
 	buf.Truncate(buf.Len() - 1) // remove trailing newline written by enc.Encode
 	return nil
 }
-
-func generate(schema *jsonschema.Schema) ([]*propertyGroup, error) {
-	if schema.Properties == nil {
-		return nil, nil
-	}
-
-	var groups []*propertyGroup
-	byName := map[string]*propertyGroup{}
-	var v jsonschema.Visitor
-	v = visitorFunc(func(schema *jsonschema.Schema, rel []jsonschema.ReferenceToken) (w jsonschema.Visitor) {
-		if schema == nil || schema.Properties == nil {
-			return
-		}
-		for name, prop := range *schema.Properties {
-			var extra struct {
-				Hide  bool
-				Group string `json:"group"`
-			}
-			if err := json.Unmarshal(*prop.Raw, &extra); err != nil {
-				panic(err)
-			}
-			if extra.Hide {
-				continue
-			}
-
-			p := property{
-				name:     name,
-				examples: prop.Examples,
-			}
-			if prop.Const != nil {
-				p.value = prop.Const
-				p.first = true // put const properties first
-			} else if prop.Default != nil {
-				p.value = prop.Default
-			}
-			if prop.Description != nil {
-				p.comment = *prop.Description
-			}
-
-			groupName := extra.Group
-			group := byName[groupName]
-			if group == nil {
-				group = &propertyGroup{name: groupName}
-				byName[groupName] = group
-				groups = append(groups, group)
-			}
-			group.properties = append(group.properties, p)
-		}
-		return nil
-	})
-	jsonschema.Walk(v, schema)
-
-	return groups, nil
-}
-
-func isType(schema *jsonschema.Schema, typ jsonschema.PrimitiveType) bool {
-	return len(schema.Type) == 1 && schema.Type[0] == typ
-}
-
-func extraField(schema *jsonschema.Schema, name string) string {
-	var m map[string]interface{}
-	if schema.Raw == nil {
-		return ""
-	}
-	if err := json.Unmarshal(*schema.Raw, &m); err != nil {
-		return ""
-	}
-	v, _ := m[name].(string)
-	return v
-}
-
-type visitorFunc func(schema *jsonschema.Schema, rel []jsonschema.ReferenceToken) (w jsonschema.Visitor)
-
-func (v visitorFunc) Visit(schema *jsonschema.Schema, rel []jsonschema.ReferenceToken) (w jsonschema.Visitor) {
-	return v(schema, rel)
-}
-
-type propertyGroup struct {
-	name       string
-	properties []property
-}
-
-// property represents a jsonschema.Schema.Properties and its name in a single structure.
-type property struct {
-	name     string        // property name
-	comment  string        // doc comment
-	value    *interface{}  // default value (or const value)
-	examples []interface{} // other example values
-	first    bool          // show this property at the top
-}
@@ -202,6 +202,145 @@ func TestGenerate(t *testing.T) {
 	//     "d44444444444444",
 	//     "d555555555555"
 	//   ]
+}`,
+		},
+		"format hint": {
+			schema: `
+{
+  "type": "object",
+  "properties": {
+    "a": {
+      "description": "b",
+      "type": "string",
+      "default": "30s",
+      "format": "duration"
+    }
+  }
+}`,
+			want: `{
+	// b
+	//
+	// Format: duration — a duration parseable by time.ParseDuration, e.g. 30s, 5m, 1h30m
+	"a": "30s"
+}`,
+		},
+
+		"allOf merges properties": {
+			schema: `
+{
+  "type": "object",
+  "properties": {
+    "a": {
+      "type": "object",
+      "allOf": [
+        {"properties": {"x": {"type": "string", "default": "1"}}},
+        {"properties": {"y": {"type": "string", "default": "2"}}}
+      ]
+    }
+  }
+}`,
+			want: `{
+	"a": null,
+
+	"x": "1",
+
+	"y": "2"
+}`,
+		},
+
+		"oneOf comment block": {
+			schema: `
+{
+  "type": "object",
+  "properties": {
+    "a": {
+      "description": "d",
+      "type": "object",
+      "oneOf": [
+        {"properties": {"x": {"type": "string", "default": "1"}}},
+        {"properties": {"y": {"type": "string", "default": "2"}}}
+      ]
+    }
+  }
+}`,
+			want: `{
+	// d
+	//
+	// One of:
+	//   1. x (e.g. {"x":"1"})
+	//   2. y (e.g. {"y":"2"})
+	"a": null
+}`,
+		},
+
+		"if/then/else note": {
+			schema: `
+{
+  "type": "object",
+  "properties": {
+    "a": {
+      "description": "d",
+      "type": "object",
+      "if": {"properties": {"kind": {"const": "foo"}}},
+      "then": {"properties": {"x": {"type": "string"}}},
+      "else": {"properties": {"y": {"type": "string"}}}
+    }
+  }
+}`,
+			want: `{
+	// d
+	//
+	// When kind is "foo":
+	//   then: x
+	//   else: y
+	"a": null
+}`,
+		},
+
+		"ref back-pointer on cycle": {
+			schema: `
+{
+  "type": "object",
+  "properties": {
+    "a": {"$ref": "#/definitions/Node"}
+  },
+  "definitions": {
+    "Node": {
+      "type": "object",
+      "properties": {
+        "next": {"$ref": "#/definitions/Node"}
+      }
+    }
+  }
+}`,
+			want: `{
+	"a": null
+}`,
+		},
+
+		"nested object properties are not flattened": {
+			schema: `
+{
+  "type": "object",
+  "properties": {
+    "server": {
+      "type": "object",
+      "properties": {
+        "timeout": {"type": "string", "default": "30s"}
+      }
+    },
+    "client": {
+      "type": "object",
+      "properties": {
+        "timeout": {"type": "string", "default": "10s"}
+      }
+    }
+  }
+}`,
+			want: `{
+	"client": null,
+
+	"server": null
 }`,
 		},
 	}
@@ -221,6 +360,23 @@ func TestGenerate(t *testing.T) {
 	}
 }
 
+func TestGenerateInvalidFormatDefault(t *testing.T) {
+	schema := parseJSONSchema(t, `
+{
+  "type": "object",
+  "properties": {
+    "a": {
+      "type": "string",
+      "default": "not-a-duration",
+      "format": "duration"
+    }
+  }
+}`)
+	if _, err := Generate(&schema); err == nil {
+		t.Fatal("want error for default value that does not satisfy its declared format")
+	}
+}
+
 func parseJSONSchema(t *testing.T, input string) jsonschema.Schema {
 	t.Helper()
 	var schema jsonschema.Schema
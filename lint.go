@@ -0,0 +1,340 @@
+package jsonschemadoc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/go-jsonschema/jsonschema"
+)
+
+// Severity is the severity of a LintIssue.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// LintIssue describes a single way in which a config value fails to conform to a JSON Schema.
+type LintIssue struct {
+	Path     string // JSON pointer path to the offending value (e.g. "/server/port")
+	Offset   int    // best-effort byte offset of the offending value's object key in the original config, or -1 if unknown
+	Severity Severity
+	Keyword  string // the failing JSON Schema keyword ("required", "type", "enum", ...)
+	Message  string // human-readable message, derived from the schema's description where possible
+
+	// Suggestion is a "did you mean" hint for enum/const/additionalProperties violations, or empty
+	// if there is no close match.
+	Suggestion string
+}
+
+// Lint validates config (JSON or JSONC, with "//" line comments) against schema and returns the
+// issues found. It does not return an error for validation failures; a non-nil error indicates
+// that config could not be parsed at all.
+func Lint(schema *jsonschema.Schema, config []byte) ([]LintIssue, error) {
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(StripJSONComments(config)))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	l := &linter{config: config}
+	l.check(schema, v, "/")
+	sort.Slice(l.issues, func(i, j int) bool { return l.issues[i].Path < l.issues[j].Path })
+	return l.issues, nil
+}
+
+type linter struct {
+	config []byte
+	issues []LintIssue
+}
+
+func (l *linter) add(path, keyword, message, suggestion string) {
+	l.issues = append(l.issues, LintIssue{
+		Path:       path,
+		Offset:     l.offsetOf(path),
+		Severity:   SeverityError,
+		Keyword:    keyword,
+		Message:    message,
+		Suggestion: suggestion,
+	})
+}
+
+// offsetOf makes a best-effort attempt to find the byte offset of the value at path by searching
+// for its final path component as a quoted JSON object key, returning the offset of that key's
+// opening quote. It returns 0 for the root path and -1 if it can't find the key.
+func (l *linter) offsetOf(path string) int {
+	name := path[strings.LastIndex(path, "/")+1:]
+	if name == "" {
+		return 0
+	}
+	needle := []byte(`"` + name + `"`)
+	return bytes.Index(l.config, needle)
+}
+
+func (l *linter) check(schema *jsonschema.Schema, v interface{}, path string) {
+	if schema == nil || schema.IsEmpty {
+		return
+	}
+	if schema.IsNegated {
+		l.add(path, "not", "value is not allowed here", "")
+		return
+	}
+
+	if len(schema.Type) > 0 && !typeMatches(schema.Type, v) {
+		l.add(path, "type", fmt.Sprintf("expected type %s, got %s", joinTypes(schema.Type), jsonTypeName(v)), "")
+		return // further checks assume the value is the expected shape
+	}
+
+	if schema.Const != nil && !jsonEqual(v, *schema.Const) {
+		l.add(path, "const", describeMismatch(schema, "must equal the constant value"), suggestEnum(jsonschema.EnumList{*schema.Const}, v))
+	}
+
+	if len(schema.Enum) > 0 && !enumContains(schema.Enum, v) {
+		l.add(path, "enum", describeMismatch(schema, "value is not one of the allowed values"), suggestEnum(schema.Enum, v))
+	}
+
+	if s, ok := v.(string); ok {
+		if schema.Pattern != nil {
+			if ok, err := regexp.MatchString(*schema.Pattern, s); err == nil && !ok {
+				l.add(path, "pattern", describeMismatch(schema, fmt.Sprintf("does not match pattern %q", *schema.Pattern)), "")
+			}
+		}
+		if schema.Format != nil {
+			if checker, known := DefaultFormatCheckers()[string(*schema.Format)]; known {
+				if ok, hint := checker(s); !ok {
+					msg := fmt.Sprintf("does not satisfy format %q: expected %s", *schema.Format, hint)
+					l.add(path, "format", describeMismatch(schema, msg), "")
+				}
+			}
+		}
+	}
+
+	if m, ok := v.(map[string]interface{}); ok {
+		for _, req := range schema.Required {
+			if _, present := m[req]; !present {
+				l.add(joinPath(path, req), "required", fmt.Sprintf("missing required property %q", req), "")
+			}
+		}
+
+		var propNames []string
+		if schema.Properties != nil {
+			for name, propSchema := range *schema.Properties {
+				propNames = append(propNames, name)
+				if val, present := m[name]; present {
+					l.check(propSchema, val, joinPath(path, name))
+				}
+			}
+		}
+
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.IsNegated {
+			known := make(map[string]bool, len(propNames))
+			for _, name := range propNames {
+				known[name] = true
+			}
+			for name := range m {
+				if !known[name] {
+					l.add(joinPath(path, name), "additionalProperties", fmt.Sprintf("%q is not a known property", name), suggestName(propNames, name))
+				}
+			}
+		}
+	}
+}
+
+func joinPath(base, name string) string {
+	if base == "/" {
+		return "/" + name
+	}
+	return base + "/" + name
+}
+
+func describeMismatch(schema *jsonschema.Schema, fallback string) string {
+	if schema.Description != nil && *schema.Description != "" {
+		return strings.TrimSpace(*schema.Description) + " (" + fallback + ")"
+	}
+	return fallback
+}
+
+func jsonTypeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+func joinTypes(types jsonschema.PrimitiveTypeList) string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = string(t)
+	}
+	return strings.Join(names, " or ")
+}
+
+func typeMatches(types jsonschema.PrimitiveTypeList, v interface{}) bool {
+	for _, t := range types {
+		switch t {
+		case jsonschema.NullType:
+			if v == nil {
+				return true
+			}
+		case jsonschema.BooleanType:
+			if _, ok := v.(bool); ok {
+				return true
+			}
+		case jsonschema.StringType:
+			if _, ok := v.(string); ok {
+				return true
+			}
+		case jsonschema.ArrayType:
+			if _, ok := v.([]interface{}); ok {
+				return true
+			}
+		case jsonschema.ObjectType:
+			if _, ok := v.(map[string]interface{}); ok {
+				return true
+			}
+		case jsonschema.NumberType:
+			if _, ok := v.(json.Number); ok {
+				return true
+			}
+		case jsonschema.IntegerType:
+			if n, ok := v.(json.Number); ok {
+				if _, err := n.Int64(); err == nil {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func jsonEqual(a, b interface{}) bool {
+	an, aIsNum := a.(json.Number)
+	bn, bIsNum := b.(float64)
+	if aIsNum && bIsNum {
+		f, err := an.Float64()
+		return err == nil && f == bn
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func enumContains(enum jsonschema.EnumList, v interface{}) bool {
+	for _, e := range enum {
+		if jsonEqual(v, e) {
+			return true
+		}
+	}
+	return false
+}
+
+func suggestEnum(enum jsonschema.EnumList, v interface{}) string {
+	s, ok := v.(string)
+	if !ok {
+		return ""
+	}
+	var candidates []string
+	for _, e := range enum {
+		if es, ok := e.(string); ok {
+			candidates = append(candidates, es)
+		}
+	}
+	return suggestName(candidates, s)
+}
+
+// suggestName returns the candidate closest to name by Levenshtein distance, or "" if none is a
+// close enough match to be a useful suggestion.
+func suggestName(candidates []string, name string) string {
+	const maxDistance = 3
+
+	best := ""
+	bestDist := maxDistance + 1
+	for _, c := range candidates {
+		d := levenshtein(name, c)
+		if d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	if bestDist > maxDistance {
+		return ""
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(curr[j-1]+1, prev[j]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	return m
+}
+
+// StripJSONComments strips "//" line comments from JSONC source, the same style that Generate
+// writes doc comments in, so that Generate's output can be round-tripped through Lint.
+func StripJSONComments(data []byte) []byte {
+	lines := bytes.Split(data, []byte("\n"))
+	for i, line := range lines {
+		if idx := indexCommentStart(line); idx >= 0 {
+			lines[i] = line[:idx]
+		}
+	}
+	return bytes.Join(lines, []byte("\n"))
+}
+
+// indexCommentStart returns the index of a "//" comment start in line, ignoring occurrences inside
+// double-quoted strings, or -1 if there is none.
+func indexCommentStart(line []byte) int {
+	inString := false
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && inString:
+			i++ // skip escaped character
+		case line[i] == '"':
+			inString = !inString
+		case !inString && i+1 < len(line) && line[i] == '/' && line[i+1] == '/':
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,105 @@
+package jsonschemadoc
+
+import (
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	tests := map[string]struct {
+		schema     string
+		config     string
+		wantIssues []LintIssue
+	}{
+		"valid": {
+			schema: `
+{
+  "type": "object",
+  "properties": {"a": {"type": "string"}},
+  "required": ["a"]
+}`,
+			config:     `{"a": "x"}`,
+			wantIssues: nil,
+		},
+
+		"missing required": {
+			schema: `
+{
+  "type": "object",
+  "properties": {"a": {"type": "string"}},
+  "required": ["a"]
+}`,
+			config: `{}`,
+			wantIssues: []LintIssue{
+				{Path: "/a", Offset: -1, Severity: SeverityError, Keyword: "required", Message: `missing required property "a"`},
+			},
+		},
+
+		"wrong type": {
+			schema: `{"type": "object", "properties": {"a": {"type": "number"}}}`,
+			config: `{"a": "x"}`,
+			wantIssues: []LintIssue{
+				{Path: "/a", Offset: 2, Severity: SeverityError, Keyword: "type", Message: "expected type number, got string"},
+			},
+		},
+
+		"enum mismatch suggests closest match": {
+			schema: `{"type": "object", "properties": {"a": {"enum": ["foo", "bar"]}}}`,
+			config: `{"a": "fo"}`,
+			wantIssues: []LintIssue{
+				{Path: "/a", Offset: 2, Severity: SeverityError, Keyword: "enum", Message: "value is not one of the allowed values", Suggestion: "foo"},
+			},
+		},
+
+		"unknown property suggests closest match": {
+			schema: `{"type": "object", "properties": {"color": {"type": "string"}}, "additionalProperties": false}`,
+			config: `{"colour": "red"}`,
+			wantIssues: []LintIssue{
+				{Path: "/colour", Offset: 2, Severity: SeverityError, Keyword: "additionalProperties", Message: `"colour" is not a known property`, Suggestion: "color"},
+			},
+		},
+
+		"strips jsonc comments": {
+			schema: `{"type": "object", "properties": {"a": {"type": "string"}}, "required": ["a"]}`,
+			config: "{\n  // a comment\n}",
+			wantIssues: []LintIssue{
+				{Path: "/a", Offset: -1, Severity: SeverityError, Keyword: "required", Message: `missing required property "a"`},
+			},
+		},
+	}
+	for label, test := range tests {
+		t.Run(label, func(t *testing.T) {
+			schema := parseJSONSchema(t, test.schema)
+			issues, err := Lint(&schema, []byte(test.config))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(issues) != len(test.wantIssues) {
+				t.Fatalf("got %d issues, want %d: %+v", len(issues), len(test.wantIssues), issues)
+			}
+			for i, want := range test.wantIssues {
+				got := issues[i]
+				if got.Path != want.Path || got.Severity != want.Severity || got.Keyword != want.Keyword || got.Message != want.Message || got.Suggestion != want.Suggestion {
+					t.Errorf("issue %d:\ngot:  %+v\nwant: %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"foo", "foo", 0},
+		{"foo", "fo", 1},
+		{"colour", "color", 1},
+		{"kitten", "sitting", 3},
+	}
+	for _, test := range tests {
+		if got := levenshtein(test.a, test.b); got != test.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", test.a, test.b, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package jsonschemadoc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/sourcegraph/go-jsonschema/jsonschema"
+)
+
+// GenerateMarkdown generates Markdown reference documentation for a JSON Schema's properties,
+// suitable for checking in as a docs artifact (e.g. `docs/config.md`).
+func GenerateMarkdown(schema *jsonschema.Schema) (string, error) {
+	doc, err := BuildDocument(schema, Options{})
+	if err != nil {
+		return "", err
+	}
+	return (&MarkdownWriter{}).Write(doc)
+}
+
+// MarkdownWriter renders a Document as Markdown reference documentation: a table of contents with
+// GitHub-style anchor slugs, followed by one section per property with its type, default, const,
+// enum, format, description, and examples. Nested object properties recurse with heading-level
+// bumps, `allOf` members are merged into the parent's own properties, `$ref`s link to the
+// referenced definition's section (or, on a cycle, note the back-pointer instead of expanding
+// further), `oneOf`/`anyOf` alternatives are rendered as their own anchored sub-sections (so
+// cross-links from descriptions can target a specific alternative), and `if`-`then`-`else` is
+// rendered as a bulleted list of the conditional properties.
+type MarkdownWriter struct{}
+
+func (w *MarkdownWriter) Write(doc *Document) (string, error) {
+	r := &markdownRenderer{anchorCounts: map[string]int{}}
+
+	for _, group := range doc.Groups {
+		for _, n := range group.Properties {
+			if err := r.renderNode(n, 2); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if len(doc.Definitions) > 0 {
+		r.heading(2, "Definitions")
+		for _, name := range sortedDefNames(doc.Definitions) {
+			if err := r.renderNode(doc.Definitions[name], 3); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	var out bytes.Buffer
+	out.WriteString(r.renderTOC())
+	out.Write(r.buf.Bytes())
+	return strings.TrimRight(out.String(), "\n") + "\n", nil
+}
+
+type markdownRenderer struct {
+	buf          bytes.Buffer
+	headings     []markdownHeading
+	anchorCounts map[string]int
+}
+
+type markdownHeading struct {
+	level  int
+	title  string
+	anchor string
+}
+
+// heading writes a Markdown heading at the given level and records it for the table of contents,
+// returning its anchor slug.
+func (r *markdownRenderer) heading(level int, title string) string {
+	anchor := r.anchorFor(title)
+	r.headings = append(r.headings, markdownHeading{level: level, title: title, anchor: anchor})
+	fmt.Fprintf(&r.buf, "%s %s\n\n", strings.Repeat("#", level), title)
+	return anchor
+}
+
+// anchorFor returns a GitHub-style anchor slug for title, disambiguating repeats the way GitHub
+// does (appending -1, -2, ...).
+func (r *markdownRenderer) anchorFor(title string) string {
+	base := slugify(title)
+	n := r.anchorCounts[base]
+	r.anchorCounts[base]++
+	if n == 0 {
+		return base
+	}
+	return fmt.Sprintf("%s-%d", base, n)
+}
+
+func (r *markdownRenderer) renderNode(n *Node, level int) error {
+	r.heading(level, n.Name)
+
+	if len(n.Type) > 0 {
+		fmt.Fprintf(&r.buf, "**Type:** `%s`\n\n", strings.Join(n.Type, " | "))
+	}
+	if n.Required {
+		r.buf.WriteString("**Required.**\n\n")
+	}
+	if n.Deprecated {
+		r.buf.WriteString("**Deprecated.**\n\n")
+	}
+	if n.Const != nil {
+		b, err := json.Marshal(*n.Const)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&r.buf, "**Const:** `%s`\n\n", b)
+	}
+	if n.Default != nil {
+		b, err := json.Marshal(*n.Default)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&r.buf, "**Default:** `%s`\n\n", b)
+	}
+	if len(n.Enum) > 0 {
+		vals := make([]string, len(n.Enum))
+		for i, e := range n.Enum {
+			b, err := json.Marshal(e)
+			if err != nil {
+				return err
+			}
+			vals[i] = fmt.Sprintf("`%s`", b)
+		}
+		fmt.Fprintf(&r.buf, "**Enum:** %s\n\n", strings.Join(vals, ", "))
+	}
+	if n.Format != "" {
+		fmt.Fprintf(&r.buf, "**Format:** `%s`\n\n", n.Format)
+	}
+	if n.Description != "" {
+		fmt.Fprintf(&r.buf, "%s\n\n", n.Description)
+	}
+	if n.Ref != "" {
+		if defName, ok := resolveDefRef(n.Ref); ok {
+			fmt.Fprintf(&r.buf, "See [%s](#%s).\n\n", defName, slugify(defName))
+		}
+	}
+	if n.RefNote != "" {
+		fmt.Fprintf(&r.buf, "%s\n\n", n.RefNote)
+	}
+	if len(n.OneOf) > 0 {
+		if err := r.renderVariants(n.Name, "One of", n.OneOf, level+1); err != nil {
+			return err
+		}
+	}
+	if len(n.AnyOf) > 0 {
+		if err := r.renderVariants(n.Name, "Any of", n.AnyOf, level+1); err != nil {
+			return err
+		}
+	}
+	if n.Conditional != nil {
+		r.renderConditional(n.Conditional)
+	}
+	if len(n.Examples) > 0 {
+		r.buf.WriteString("Examples:\n\n")
+		for _, x := range n.Examples {
+			b, err := json.MarshalIndent(x, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(&r.buf, "```json\n%s\n```\n\n", b)
+		}
+	}
+
+	for _, child := range n.Children {
+		if err := r.renderNode(child, level+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// renderVariants renders a oneOf/anyOf alternative list as anchored sub-sections, one per
+// variant, so descriptions elsewhere in the document can cross-link to a specific alternative.
+func (r *markdownRenderer) renderVariants(name, label string, variants []Variant, level int) error {
+	r.heading(level, fmt.Sprintf("%s: %s", name, label))
+	for i, v := range variants {
+		r.heading(level+1, fmt.Sprintf("Option %d", i+1))
+		if v.RefNote != "" {
+			fmt.Fprintf(&r.buf, "%s\n\n", v.RefNote)
+			continue
+		}
+		if v.Example != nil {
+			if ex, err := json.Marshal(v.Example); err == nil {
+				fmt.Fprintf(&r.buf, "e.g. `%s`\n\n", ex)
+			}
+		}
+		for _, p := range v.Properties {
+			if err := r.renderNode(p, level+2); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// renderConditional renders an if/then/else note describing which properties apply depending on
+// the condition.
+func (r *markdownRenderer) renderConditional(c *Conditional) {
+	fmt.Fprintf(&r.buf, "**When %s:**\n\n", c.Condition)
+	if len(c.Then) > 0 {
+		fmt.Fprintf(&r.buf, "- then: %s\n", strings.Join(nodeNames(c.Then), ", "))
+	}
+	if len(c.Else) > 0 {
+		fmt.Fprintf(&r.buf, "- else: %s\n", strings.Join(nodeNames(c.Else), ", "))
+	}
+	r.buf.WriteByte('\n')
+}
+
+func (r *markdownRenderer) renderTOC() string {
+	var b strings.Builder
+	b.WriteString("## Table of Contents\n\n")
+	for _, h := range r.headings {
+		fmt.Fprintf(&b, "%s- [%s](#%s)\n", strings.Repeat("  ", h.level-2), h.title, h.anchor)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// resolveDefRef returns the definition name that ref points to, if ref is a `#/definitions/<name>`
+// or `#/$defs/<name>` reference.
+func resolveDefRef(ref string) (name string, ok bool) {
+	for _, prefix := range []string{"#/definitions/", "#/$defs/"} {
+		if strings.HasPrefix(ref, prefix) {
+			return strings.TrimPrefix(ref, prefix), true
+		}
+	}
+	return "", false
+}
+
+// slugify converts text to a GitHub-style anchor slug: lowercased, with non-word characters
+// stripped and spaces replaced by dashes.
+func slugify(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case r == ' ' || r == '-':
+			b.WriteByte('-')
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func sortedDefNames(m map[string]*Node) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
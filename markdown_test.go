@@ -0,0 +1,171 @@
+package jsonschemadoc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateMarkdown(t *testing.T) {
+	tests := map[string]struct {
+		schema string
+		want   string
+	}{
+		"single property": {
+			schema: `
+{
+  "type": "object",
+  "properties": {
+    "a": {
+      "description": "b",
+      "type": "string",
+      "default": "c"
+    }
+  }
+}`,
+			want: `
+## Table of Contents
+
+- [a](#a)
+
+## a
+
+**Type:** ` + "`string`" + `
+
+**Default:** ` + "`\"c\"`" + `
+
+b
+`,
+		},
+
+		"nested object": {
+			schema: `
+{
+  "type": "object",
+  "properties": {
+    "a": {
+      "type": "object",
+      "properties": {
+        "b": {"type": "number"}
+      }
+    }
+  }
+}`,
+			want: `
+## Table of Contents
+
+- [a](#a)
+  - [b](#b)
+
+## a
+
+**Type:** ` + "`object`" + `
+
+### b
+
+**Type:** ` + "`number`" + `
+`,
+		},
+
+		"ref to definition": {
+			schema: `
+{
+  "type": "object",
+  "properties": {
+    "a": {"$ref": "#/definitions/Widget"}
+  },
+  "definitions": {
+    "Widget": {"type": "string", "description": "a widget"}
+  }
+}`,
+			want: `
+## Table of Contents
+
+- [a](#a)
+- [Definitions](#definitions)
+  - [Widget](#widget)
+
+## a
+
+**Type:** ` + "`string`" + `
+
+a widget
+
+See [Widget](#widget).
+
+## Definitions
+
+### Widget
+
+**Type:** ` + "`string`" + `
+
+a widget
+`,
+		},
+
+		"oneOf variants": {
+			schema: `
+{
+  "type": "object",
+  "properties": {
+    "a": {
+      "type": "object",
+      "oneOf": [
+        {"properties": {"x": {"type": "string", "default": "1"}}},
+        {"properties": {"y": {"type": "string", "default": "2"}}}
+      ]
+    }
+  }
+}`,
+			want: `
+## Table of Contents
+
+- [a](#a)
+  - [a: One of](#a-one-of)
+    - [Option 1](#option-1)
+      - [x](#x)
+    - [Option 2](#option-2)
+      - [y](#y)
+
+## a
+
+**Type:** ` + "`object`" + `
+
+### a: One of
+
+#### Option 1
+
+e.g. ` + "`{\"x\":\"1\"}`" + `
+
+##### x
+
+**Type:** ` + "`string`" + `
+
+**Default:** ` + "`\"1\"`" + `
+
+#### Option 2
+
+e.g. ` + "`{\"y\":\"2\"}`" + `
+
+##### y
+
+**Type:** ` + "`string`" + `
+
+**Default:** ` + "`\"2\"`" + `
+`,
+		},
+	}
+	for label, test := range tests {
+		t.Run(label, func(t *testing.T) {
+			schema := parseJSONSchema(t, test.schema)
+			out, err := GenerateMarkdown(&schema)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out = strings.TrimSpace(out)
+			test.want = strings.TrimSpace(test.want)
+			if out != test.want {
+				t.Errorf("wrong output\n\ngot:\n%s\n\nwant:\n%s", out, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package jsonschemadoc
+
+import (
+	"encoding/json"
+
+	"github.com/sourcegraph/go-jsonschema/jsonschema"
+)
+
+// GenerateModel generates a machine-readable JSON dump of the Document model itself, for
+// consumers that want to build their own renderer (e.g. an editor tooltip provider) without
+// re-walking the schema.
+func GenerateModel(schema *jsonschema.Schema) (string, error) {
+	doc, err := BuildDocument(schema, Options{})
+	if err != nil {
+		return "", err
+	}
+	return (&ModelWriter{}).Write(doc)
+}
+
+// ModelWriter renders a Document as an indented JSON dump of the model itself.
+type ModelWriter struct{}
+
+func (w *ModelWriter) Write(doc *Document) (string, error) {
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,36 @@
+package jsonschemadoc
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGenerateModel(t *testing.T) {
+	schema := parseJSONSchema(t, `
+{
+  "type": "object",
+  "properties": {
+    "a": {
+      "description": "b",
+      "type": "string",
+      "default": "c"
+    }
+  }
+}`)
+	out, err := GenerateModel(&schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc Document
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("output is not valid JSON: %s", err)
+	}
+	if len(doc.Groups) != 1 || len(doc.Groups[0].Properties) != 1 {
+		t.Fatalf("got %+v, want one group with one property", doc.Groups)
+	}
+	prop := doc.Groups[0].Properties[0]
+	if prop.Name != "a" || prop.Description != "b" {
+		t.Errorf("got %+v, want property \"a\" with description \"b\"", prop)
+	}
+}